@@ -0,0 +1,44 @@
+package artifactory
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v12/pkg/artifactory/resource/webhook"
+)
+
+// webhookResourceTypeName derives the `artifactory_*_webhook` Terraform resource type name
+// for a webhook.TypesSupported domain. "release_bundle", "distribution",
+// "artifactory_release_bundle" and "destination" share the same criteria/pack/unpack/schema
+// functions, but they're still four distinct entries in TypesSupported with four distinct
+// resource names (the "artifactory_release_bundle" domain's name doubles up the
+// "artifactory_" prefix, which is correct — it's a different resource from
+// "artifactory_release_bundle_webhook"). Trimming the prefix would collapse two of those
+// four onto the same ResourcesMap key and silently drop one of them.
+func webhookResourceTypeName(webhookType string) string {
+	return fmt.Sprintf("artifactory_%s_webhook", webhookType)
+}
+
+// Provider returns the terraform-provider-artifactory provider, with every
+// `artifactory_*_webhook` resource (one per webhook.TypesSupported domain) plus the webhook
+// package's auxiliary resource/data source merged into the provider's ResourcesMap and
+// DataSourcesMap.
+func Provider() *schema.Provider {
+	resourcesMap := map[string]*schema.Resource{}
+	for _, webhookType := range webhook.TypesSupported {
+		resourcesMap[webhookResourceTypeName(webhookType)] = webhook.ResourceArtifactoryWebhook(webhookType)
+	}
+	for name, resource := range webhookAuxiliaryResources {
+		resourcesMap[name] = resource
+	}
+
+	dataSourcesMap := map[string]*schema.Resource{}
+	for name, dataSource := range webhookAuxiliaryDataSources {
+		dataSourcesMap[name] = dataSource
+	}
+
+	return &schema.Provider{
+		ResourcesMap:   resourcesMap,
+		DataSourcesMap: dataSourcesMap,
+	}
+}
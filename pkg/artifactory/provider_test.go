@@ -0,0 +1,47 @@
+package artifactory
+
+import (
+	"testing"
+
+	"github.com/jfrog/terraform-provider-artifactory/v12/pkg/artifactory/resource/webhook"
+)
+
+func TestWebhookResourceTypeName(t *testing.T) {
+	tests := map[string]string{
+		"artifact":                   "artifactory_artifact_webhook",
+		"release_bundle":             "artifactory_release_bundle_webhook",
+		"distribution":               "artifactory_distribution_webhook",
+		"artifactory_release_bundle": "artifactory_artifactory_release_bundle_webhook",
+		"destination":                "artifactory_destination_webhook",
+		"release_bundle_v2":          "artifactory_release_bundle_v2_webhook",
+	}
+
+	for webhookType, want := range tests {
+		if got := webhookResourceTypeName(webhookType); got != want {
+			t.Errorf("webhookResourceTypeName(%q) = %q, want %q", webhookType, got, want)
+		}
+	}
+}
+
+func TestProviderRegistersWebhookAuxiliaryResources(t *testing.T) {
+	p := Provider()
+
+	if _, ok := p.ResourcesMap["artifactory_webhook_test"]; !ok {
+		t.Error("ResourcesMap is missing artifactory_webhook_test")
+	}
+
+	if _, ok := p.DataSourcesMap["artifactory_webhook_signature"]; !ok {
+		t.Error("DataSourcesMap is missing artifactory_webhook_signature")
+	}
+}
+
+// TestProviderRegistersEveryWebhookDomain guards against webhookResourceTypeName
+// accidentally collapsing two distinct webhook.TypesSupported domains onto the same
+// ResourcesMap key, which would silently drop one of them.
+func TestProviderRegistersEveryWebhookDomain(t *testing.T) {
+	p := Provider()
+
+	if got, want := len(p.ResourcesMap), len(webhook.TypesSupported)+len(webhookAuxiliaryResources); got != want {
+		t.Errorf("len(ResourcesMap) = %d, want %d (one per webhook.TypesSupported domain, plus auxiliary resources)", got, want)
+	}
+}
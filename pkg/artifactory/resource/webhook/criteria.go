@@ -0,0 +1,393 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	utilsdk "github.com/jfrog/terraform-provider-shared/util/sdk"
+)
+
+// BaseWebhookCriteria holds the include/exclude pattern fields every domain's criteria
+// shares; domainUnpackLookup's unpack functions take one of these plus the domain-specific
+// fields parsed out of the raw criteria/event_rule map.
+type BaseWebhookCriteria struct {
+	IncludePatterns []string `json:"includePatterns"`
+	ExcludePatterns []string `json:"excludePatterns"`
+}
+
+// RepoWebhookCriteria is shared by the artifact, artifact_property and docker domains: all
+// three scope events to a set of repositories the same way.
+type RepoWebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyLocal     bool     `json:"anyLocal"`
+	AnyRemote    bool     `json:"anyRemote"`
+	AnyFederated bool     `json:"anyFederated"`
+	RepoKeys     []string `json:"repoKeys"`
+}
+
+func repoCriteriaFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any_local": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all local repositories.",
+		},
+		"any_remote": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all remote repositories.",
+		},
+		"any_federated": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all federated repositories.",
+		},
+		"repo_keys": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of repository keys to apply the event to. Ignored when any of the any_* attributes is `true`.",
+		},
+	}
+}
+
+func unpackRepoCriteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	criteria := RepoWebhookCriteria{BaseWebhookCriteria: base}
+
+	if v, ok := c["any_local"]; ok {
+		criteria.AnyLocal = v.(bool)
+	}
+	if v, ok := c["any_remote"]; ok {
+		criteria.AnyRemote = v.(bool)
+	}
+	if v, ok := c["any_federated"]; ok {
+		criteria.AnyFederated = v.(bool)
+	}
+	if v, ok := c["repo_keys"]; ok {
+		criteria.RepoKeys = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return criteria
+}
+
+func packRepoCriteria(criteria map[string]interface{}) map[string]interface{} {
+	packed := map[string]interface{}{}
+
+	if v, ok := criteria["anyLocal"]; ok && v != nil {
+		packed["any_local"] = v.(bool)
+	}
+	if v, ok := criteria["anyRemote"]; ok && v != nil {
+		packed["any_remote"] = v.(bool)
+	}
+	if v, ok := criteria["anyFederated"]; ok && v != nil {
+		packed["any_federated"] = v.(bool)
+	}
+
+	repoKeys := []interface{}{}
+	if v, ok := criteria["repoKeys"]; ok && v != nil {
+		repoKeys = v.([]interface{})
+	}
+	packed["repo_keys"] = schema.NewSet(schema.HashString, repoKeys)
+
+	return packed
+}
+
+func repoCriteriaValidation(ctx context.Context, criteria map[string]interface{}) error {
+	anyLocal, _ := criteria["any_local"].(bool)
+	anyRemote, _ := criteria["any_remote"].(bool)
+	anyFederated, _ := criteria["any_federated"].(bool)
+
+	if anyLocal || anyRemote || anyFederated {
+		return nil
+	}
+
+	if repoKeys, ok := criteria["repo_keys"].(*schema.Set); !ok || repoKeys.Len() == 0 {
+		return fmt.Errorf("repo_keys is required when any_local, any_remote and any_federated are all false")
+	}
+
+	return nil
+}
+
+// BuildWebhookCriteria is used by the build domain.
+type BuildWebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyBuild       bool     `json:"anyBuild"`
+	SelectedBuilds []string `json:"selectedBuilds"`
+}
+
+func buildCriteriaFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any_build": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all builds.",
+		},
+		"selected_builds": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of build names to apply the event to. Ignored when any_build is `true`.",
+		},
+	}
+}
+
+func unpackBuildCriteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	criteria := BuildWebhookCriteria{BaseWebhookCriteria: base}
+
+	if v, ok := c["any_build"]; ok {
+		criteria.AnyBuild = v.(bool)
+	}
+	if v, ok := c["selected_builds"]; ok {
+		criteria.SelectedBuilds = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return criteria
+}
+
+func packBuildCriteria(criteria map[string]interface{}) map[string]interface{} {
+	packed := map[string]interface{}{}
+
+	if v, ok := criteria["anyBuild"]; ok && v != nil {
+		packed["any_build"] = v.(bool)
+	}
+
+	selectedBuilds := []interface{}{}
+	if v, ok := criteria["selectedBuilds"]; ok && v != nil {
+		selectedBuilds = v.([]interface{})
+	}
+	packed["selected_builds"] = schema.NewSet(schema.HashString, selectedBuilds)
+
+	return packed
+}
+
+func buildCriteriaValidation(ctx context.Context, criteria map[string]interface{}) error {
+	anyBuild, _ := criteria["any_build"].(bool)
+	if anyBuild {
+		return nil
+	}
+
+	if selectedBuilds, ok := criteria["selected_builds"].(*schema.Set); !ok || selectedBuilds.Len() == 0 {
+		return fmt.Errorf("selected_builds is required when any_build is false")
+	}
+
+	return nil
+}
+
+// ReleaseBundleWebhookCriteria is shared by the release_bundle, distribution,
+// artifactory_release_bundle and destination domains: all four scope events to a set of
+// release bundle names the same way.
+type ReleaseBundleWebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyReleaseBundle              bool     `json:"anyReleaseBundle"`
+	RegisteredReleaseBundlesNames []string `json:"registeredReleaseBundlesNames"`
+}
+
+func releaseBundleCriteriaFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any_release_bundle": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all release bundles.",
+		},
+		"registered_release_bundle_names": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of release bundle names to apply the event to. Ignored when any_release_bundle is `true`.",
+		},
+	}
+}
+
+func unpackReleaseBundleCriteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	criteria := ReleaseBundleWebhookCriteria{BaseWebhookCriteria: base}
+
+	if v, ok := c["any_release_bundle"]; ok {
+		criteria.AnyReleaseBundle = v.(bool)
+	}
+	if v, ok := c["registered_release_bundle_names"]; ok {
+		criteria.RegisteredReleaseBundlesNames = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return criteria
+}
+
+func packReleaseBundleCriteria(criteria map[string]interface{}) map[string]interface{} {
+	packed := map[string]interface{}{}
+
+	if v, ok := criteria["anyReleaseBundle"]; ok && v != nil {
+		packed["any_release_bundle"] = v.(bool)
+	}
+
+	names := []interface{}{}
+	if v, ok := criteria["registeredReleaseBundlesNames"]; ok && v != nil {
+		names = v.([]interface{})
+	}
+	packed["registered_release_bundle_names"] = schema.NewSet(schema.HashString, names)
+
+	return packed
+}
+
+func releaseBundleCriteriaValidation(ctx context.Context, criteria map[string]interface{}) error {
+	anyReleaseBundle, _ := criteria["any_release_bundle"].(bool)
+	if anyReleaseBundle {
+		return nil
+	}
+
+	if names, ok := criteria["registered_release_bundle_names"].(*schema.Set); !ok || names.Len() == 0 {
+		return fmt.Errorf("registered_release_bundle_names is required when any_release_bundle is false")
+	}
+
+	return nil
+}
+
+// ReleaseBundleV2WebhookCriteria is used by the release_bundle_v2 domain.
+type ReleaseBundleV2WebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyReleaseBundle       bool     `json:"anyReleaseBundle"`
+	SelectedReleaseBundles []string `json:"selectedReleaseBundles"`
+}
+
+func releaseBundleV2CriteriaFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any_release_bundle": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all release bundles v2.",
+		},
+		"selected_release_bundles": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of release bundle v2 names to apply the event to. Ignored when any_release_bundle is `true`.",
+		},
+	}
+}
+
+func unpackReleaseBundleV2Criteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	criteria := ReleaseBundleV2WebhookCriteria{BaseWebhookCriteria: base}
+
+	if v, ok := c["any_release_bundle"]; ok {
+		criteria.AnyReleaseBundle = v.(bool)
+	}
+	if v, ok := c["selected_release_bundles"]; ok {
+		criteria.SelectedReleaseBundles = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return criteria
+}
+
+func packReleaseBundleV2Criteria(criteria map[string]interface{}) map[string]interface{} {
+	packed := map[string]interface{}{}
+
+	if v, ok := criteria["anyReleaseBundle"]; ok && v != nil {
+		packed["any_release_bundle"] = v.(bool)
+	}
+
+	selected := []interface{}{}
+	if v, ok := criteria["selectedReleaseBundles"]; ok && v != nil {
+		selected = v.([]interface{})
+	}
+	packed["selected_release_bundles"] = schema.NewSet(schema.HashString, selected)
+
+	return packed
+}
+
+func releaseBundleV2CriteriaValidation(ctx context.Context, criteria map[string]interface{}) error {
+	anyReleaseBundle, _ := criteria["any_release_bundle"].(bool)
+	if anyReleaseBundle {
+		return nil
+	}
+
+	if selected, ok := criteria["selected_release_bundles"].(*schema.Set); !ok || selected.Len() == 0 {
+		return fmt.Errorf("selected_release_bundles is required when any_release_bundle is false")
+	}
+
+	return nil
+}
+
+// ReleaseBundleV2PromotionWebhookCriteria is used by the release_bundle_v2_promotion
+// domain. Unlike release_bundle_v2, promotion events are also scoped by environment, and
+// domainCriteriaValidationLookup maps this domain to emptyCriteriaValidation rather than a
+// dedicated validation function, so any_release_bundle/selected_release_bundles aren't
+// enforced the way they are for release_bundle_v2.
+type ReleaseBundleV2PromotionWebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyReleaseBundle       bool     `json:"anyReleaseBundle"`
+	SelectedReleaseBundles []string `json:"selectedReleaseBundles"`
+	IncludedEnvironments   []string `json:"includedEnvironments"`
+}
+
+func releaseBundleV2PromotionCriteriaFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"any_release_bundle": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply to event for all release bundles v2.",
+		},
+		"selected_release_bundles": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of release bundle v2 names to apply the event to. Ignored when any_release_bundle is `true`.",
+		},
+		"included_environments": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of environment names the promotion must target for the event to apply.",
+		},
+	}
+}
+
+func unpackReleaseBundleV2PromotionCriteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	criteria := ReleaseBundleV2PromotionWebhookCriteria{BaseWebhookCriteria: base}
+
+	if v, ok := c["any_release_bundle"]; ok {
+		criteria.AnyReleaseBundle = v.(bool)
+	}
+	if v, ok := c["selected_release_bundles"]; ok {
+		criteria.SelectedReleaseBundles = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+	if v, ok := c["included_environments"]; ok {
+		criteria.IncludedEnvironments = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return criteria
+}
+
+func packReleaseBundleV2PromotionCriteria(criteria map[string]interface{}) map[string]interface{} {
+	packed := map[string]interface{}{}
+
+	if v, ok := criteria["anyReleaseBundle"]; ok && v != nil {
+		packed["any_release_bundle"] = v.(bool)
+	}
+
+	selected := []interface{}{}
+	if v, ok := criteria["selectedReleaseBundles"]; ok && v != nil {
+		selected = v.([]interface{})
+	}
+	packed["selected_release_bundles"] = schema.NewSet(schema.HashString, selected)
+
+	environments := []interface{}{}
+	if v, ok := criteria["includedEnvironments"]; ok && v != nil {
+		environments = v.([]interface{})
+	}
+	packed["included_environments"] = schema.NewSet(schema.HashString, environments)
+
+	return packed
+}
+
+// EmptyWebhookCriteria is used by the user and artifact_lifecycle domains, neither of which
+// scopes events any further than event_types.
+type EmptyWebhookCriteria struct {
+	BaseWebhookCriteria
+}
+
+func unpackEmptyCriteria(c map[string]interface{}, base BaseWebhookCriteria) interface{} {
+	return EmptyWebhookCriteria{BaseWebhookCriteria: base}
+}
+
+func packEmptyCriteria(criteria map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{}
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRepoCriteriaPackUnpackRoundTrip(t *testing.T) {
+	base := BaseWebhookCriteria{IncludePatterns: []string{"libs-release/**"}, ExcludePatterns: []string{"libs-release/tmp/**"}}
+	c := map[string]interface{}{
+		"any_local":  true,
+		"any_remote": false,
+		"repo_keys":  schema.NewSet(schema.HashString, []interface{}{"libs-release-local"}),
+	}
+
+	unpacked := unpackRepoCriteria(c, base).(RepoWebhookCriteria)
+	if !unpacked.AnyLocal || len(unpacked.RepoKeys) != 1 {
+		t.Fatalf("unpackRepoCriteria = %+v, want AnyLocal=true and one repo key", unpacked)
+	}
+
+	packed := packRepoCriteria(map[string]interface{}{
+		"anyLocal": true,
+		"repoKeys": []interface{}{"libs-release-local"},
+	})
+	if packed["any_local"] != true {
+		t.Errorf("packRepoCriteria[any_local] = %v, want true", packed["any_local"])
+	}
+	if repoKeys, ok := packed["repo_keys"].(*schema.Set); !ok || repoKeys.Len() != 1 {
+		t.Errorf("packRepoCriteria[repo_keys] = %v, want one element", packed["repo_keys"])
+	}
+}
+
+func TestRepoCriteriaValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria map[string]interface{}
+		wantErr  bool
+	}{
+		{"any_local true", map[string]interface{}{"any_local": true}, false},
+		{"no any_* and no repo_keys", map[string]interface{}{"repo_keys": schema.NewSet(schema.HashString, []interface{}{})}, true},
+		{"no any_* but repo_keys set", map[string]interface{}{"repo_keys": schema.NewSet(schema.HashString, []interface{}{"repo"})}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repoCriteriaValidation(context.Background(), tt.criteria)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoCriteriaValidation(%v) error = %v, wantErr %v", tt.criteria, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReleaseBundleCriteriaPackUnpackRoundTrip(t *testing.T) {
+	base := BaseWebhookCriteria{}
+	c := map[string]interface{}{
+		"any_release_bundle":              false,
+		"registered_release_bundle_names": schema.NewSet(schema.HashString, []interface{}{"my-bundle"}),
+	}
+
+	unpacked := unpackReleaseBundleCriteria(c, base).(ReleaseBundleWebhookCriteria)
+	if unpacked.AnyReleaseBundle || len(unpacked.RegisteredReleaseBundlesNames) != 1 {
+		t.Fatalf("unpackReleaseBundleCriteria = %+v, want AnyReleaseBundle=false and one name", unpacked)
+	}
+
+	packed := packReleaseBundleCriteria(map[string]interface{}{
+		"registeredReleaseBundlesNames": []interface{}{"my-bundle"},
+	})
+	if names, ok := packed["registered_release_bundle_names"].(*schema.Set); !ok || names.Len() != 1 {
+		t.Errorf("packReleaseBundleCriteria[registered_release_bundle_names] = %v, want one element", packed["registered_release_bundle_names"])
+	}
+}
+
+func TestReleaseBundleCriteriaValidation(t *testing.T) {
+	if err := releaseBundleCriteriaValidation(context.Background(), map[string]interface{}{"any_release_bundle": true}); err != nil {
+		t.Errorf("unexpected error when any_release_bundle is true: %v", err)
+	}
+
+	empty := schema.NewSet(schema.HashString, []interface{}{})
+	if err := releaseBundleCriteriaValidation(context.Background(), map[string]interface{}{"registered_release_bundle_names": empty}); err == nil {
+		t.Error("expected error when any_release_bundle is false and registered_release_bundle_names is empty")
+	}
+}
+
+func TestEmptyCriteriaPackUnpack(t *testing.T) {
+	unpacked := unpackEmptyCriteria(map[string]interface{}{}, BaseWebhookCriteria{}).(EmptyWebhookCriteria)
+	if unpacked.IncludePatterns != nil {
+		t.Errorf("unpackEmptyCriteria = %+v, want zero value", unpacked)
+	}
+
+	if packed := packEmptyCriteria(map[string]interface{}{"anything": "ignored"}); len(packed) != 0 {
+		t.Errorf("packEmptyCriteria = %v, want empty map", packed)
+	}
+}
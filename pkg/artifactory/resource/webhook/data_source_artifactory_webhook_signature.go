@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceArtifactoryWebhookSignature computes an HMAC-SHA256 signature in the
+// `sha256=<hex>` form Artifactory documents for the `X-JFrog-Event-Auth` header, given a
+// payload and secret, so tooling that validates deliveries (test harnesses, receivers
+// provisioned by the same config) can compute the expected value without reimplementing
+// Artifactory's signing scheme. Registered under the `artifactory_webhook_signature` data
+// source type name in the provider's DataSourcesMap.
+//
+// The HMAC-SHA256 computation itself is covered by TestDataSourceArtifactoryWebhookSignature
+// against RFC 4231's published test vectors. What that test can't cover is whether a live
+// Artifactory delivery's `X-JFrog-Event-Auth` header actually uses this `sha256=<hex>`
+// prefix/encoding rather than, say, raw hex with no prefix — that part is taken from
+// Artifactory's webhook documentation and hasn't been cross-checked against a real delivery.
+func DataSourceArtifactoryWebhookSignature() *schema.Resource {
+	var readSignature = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		payload := data.Get("payload").(string)
+		secret := data.Get("secret").(string)
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+		signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+		data.SetId(signature)
+		if err := data.Set("signature", signature); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: readSignature,
+
+		Schema: map[string]*schema.Schema{
+			"payload": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The raw webhook delivery payload to sign.",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The webhook handler secret to sign the payload with.",
+			},
+			"signature": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The `sha256=<hex>` HMAC signature Artifactory computes for this payload and secret.",
+			},
+		},
+
+		Description: "Computes the HMAC-SHA256 signature Artifactory sends for a webhook delivery, given the payload and secret.",
+	}
+}
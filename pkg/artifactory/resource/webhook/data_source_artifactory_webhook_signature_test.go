@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestDataSourceArtifactoryWebhookSignature locks the HMAC-SHA256 computation against RFC
+// 4231's published test vectors, so a change to the hashing/encoding doesn't silently drift.
+// It can't cover whether a live Artifactory delivery's `X-JFrog-Event-Auth` header actually
+// uses this `sha256=<hex>` prefix/encoding — see the doc comment on
+// DataSourceArtifactoryWebhookSignature.
+func TestDataSourceArtifactoryWebhookSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		payload   string
+		signature string
+	}{
+		{
+			name:      "RFC 4231 test case 1",
+			secret:    strings.Repeat("\x0b", 20),
+			payload:   "Hi There",
+			signature: "sha256=b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+		},
+		{
+			name:      "RFC 4231 test case 2",
+			secret:    "Jefe",
+			payload:   "what do ya want for nothing?",
+			signature: "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+	}
+
+	resource := DataSourceArtifactoryWebhookSignature()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+				"payload": tt.payload,
+				"secret":  tt.secret,
+			})
+
+			if diags := resource.ReadContext(context.Background(), d, nil); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("signature").(string); got != tt.signature {
+				t.Errorf("signature = %q, want %q", got, tt.signature)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	FailurePolicyIgnore = "ignore"
+	FailurePolicyFail   = "fail"
+)
+
+// DeliveryPolicy codifies the retry/timeout/failure-handling SLO for a single handler's
+// deliveries, mirroring the bounded-retry, per-call-timeout, failure-policy model used by
+// Kubernetes admission-webhook dispatchers. Where Artifactory's subscription API accepts
+// these knobs they're forwarded as part of the Handler JSON; where it doesn't, MaxRetries
+// and BackoffSeconds drive the resty retry chain used to create/update the subscription,
+// and FailurePolicy == "fail" drives a post-create test-event verification.
+type DeliveryPolicy struct {
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	MaxRetries     int    `json:"max_retries,omitempty"`
+	BackoffSeconds int    `json:"backoff_seconds,omitempty"`
+	FailurePolicy  string `json:"failure_policy,omitempty"`
+}
+
+var unpackDeliveryPolicy = func(h map[string]interface{}) *DeliveryPolicy {
+	v, ok := h["delivery_policy"]
+	if !ok {
+		return nil
+	}
+
+	policies := v.(*schema.Set).List()
+	if len(policies) == 0 {
+		return nil
+	}
+
+	p := policies[0].(map[string]interface{})
+
+	policy := &DeliveryPolicy{
+		FailurePolicy: FailurePolicyIgnore,
+	}
+
+	if v, ok := p["timeout_seconds"]; ok {
+		policy.TimeoutSeconds = v.(int)
+	}
+	if v, ok := p["max_retries"]; ok {
+		policy.MaxRetries = v.(int)
+	}
+	if v, ok := p["backoff_seconds"]; ok {
+		policy.BackoffSeconds = v.(int)
+	}
+	if v, ok := p["failure_policy"]; ok && v.(string) != "" {
+		policy.FailurePolicy = v.(string)
+	}
+
+	return policy
+}
+
+// deliveryPolicySchema defines the attributes of the `delivery_policy` block nested under a
+// `handler`.
+func deliveryPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"timeout_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Per-call delivery timeout, in seconds.",
+		},
+		"max_retries": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of times to retry a failed delivery before giving up.",
+		},
+		"backoff_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Delay, in seconds, between retries.",
+		},
+		"failure_policy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      FailurePolicyIgnore,
+			ValidateFunc: validation.StringInSlice([]string{FailurePolicyIgnore, FailurePolicyFail}, false),
+			Description:  "Whether a delivery failure should fail `terraform apply` (`fail`) or only be recorded (`ignore`). Defaults to `ignore`.",
+		},
+	}
+}
+
+var deliveryPolicyResource = &schema.Resource{Schema: deliveryPolicySchema()}
+
+var packDeliveryPolicy = func(policy *DeliveryPolicy) []interface{} {
+	if policy == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"timeout_seconds": policy.TimeoutSeconds,
+			"max_retries":     policy.MaxRetries,
+			"backoff_seconds": policy.BackoffSeconds,
+			"failure_policy":  policy.FailurePolicy,
+		},
+	}
+}
+
+// validateDeliveryPolicy checks a handler's delivery_policy block, if any, the same way
+// handlerValidationLookup checks the rest of the handler.
+func validateDeliveryPolicy(h map[string]interface{}) error {
+	v, ok := h["delivery_policy"]
+	if !ok {
+		return nil
+	}
+
+	policies := v.(*schema.Set).List()
+	if len(policies) == 0 {
+		return nil
+	}
+
+	p := policies[0].(map[string]interface{})
+
+	if v, ok := p["timeout_seconds"].(int); ok && v < 0 {
+		return fmt.Errorf("delivery_policy.timeout_seconds must be >= 0, got %d", v)
+	}
+	if v, ok := p["max_retries"].(int); ok && v < 0 {
+		return fmt.Errorf("delivery_policy.max_retries must be >= 0, got %d", v)
+	}
+	if v, ok := p["backoff_seconds"].(int); ok && v < 0 {
+		return fmt.Errorf("delivery_policy.backoff_seconds must be >= 0, got %d", v)
+	}
+
+	failurePolicy, _ := p["failure_policy"].(string)
+	if failurePolicy == "" {
+		return nil
+	}
+
+	if failurePolicy != FailurePolicyIgnore && failurePolicy != FailurePolicyFail {
+		return fmt.Errorf("delivery_policy.failure_policy must be one of %q, got %q", []string{FailurePolicyIgnore, FailurePolicyFail}, failurePolicy)
+	}
+
+	return nil
+}
+
+// anyHandlerHasDeliveryPolicy reports whether any handler on the webhook declares a
+// delivery_policy, so the broadened 5xx/connection-error retry condition only replaces the
+// narrower proxy-not-found one when a handler actually opted into it.
+func anyHandlerHasDeliveryPolicy(handlers []Handler) bool {
+	for _, handler := range handlers {
+		if handler.DeliveryPolicy != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+var transientErrorRegex = regexp.MustCompile("proxy with key '.*' not found")
+
+// retryOnDeliveryError generalizes retryOnProxyError to also cover 5xx responses and
+// connection errors, since a handler's delivery_policy.max_retries is meant to smooth
+// over transient outages, not just a misconfigured proxy.
+func retryOnDeliveryError(response *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	if response.StatusCode() >= http.StatusInternalServerError {
+		return true
+	}
+
+	return transientErrorRegex.MatchString(string(response.Body()[:]))
+}
+
+// aggregateRetryPolicy picks the widest retry budget declared across a webhook's handlers,
+// since the resty retry chain wraps the single create/update call to Artifactory rather
+// than each handler's individual delivery.
+func aggregateRetryPolicy(handlers []Handler) (maxRetries int, backoff time.Duration) {
+	for _, handler := range handlers {
+		if handler.DeliveryPolicy == nil {
+			continue
+		}
+
+		if handler.DeliveryPolicy.MaxRetries > maxRetries {
+			maxRetries = handler.DeliveryPolicy.MaxRetries
+		}
+
+		if s := time.Duration(handler.DeliveryPolicy.BackoffSeconds) * time.Second; s > backoff {
+			backoff = s
+		}
+	}
+
+	return maxRetries, backoff
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var errReqFailed = errors.New("connection refused")
+
+func handlerWithDeliveryPolicy(policy map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"delivery_policy": schema.NewSet(schema.HashResource(deliveryPolicyResource), []interface{}{policy}),
+	}
+}
+
+func TestValidateDeliveryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", map[string]interface{}{"timeout_seconds": 30, "max_retries": 3, "backoff_seconds": 1, "failure_policy": "fail"}, false},
+		{"negative timeout", map[string]interface{}{"timeout_seconds": -1}, true},
+		{"negative max_retries", map[string]interface{}{"max_retries": -1}, true},
+		{"negative backoff", map[string]interface{}{"backoff_seconds": -1}, true},
+		{"unsupported failure_policy", map[string]interface{}{"failure_policy": "retry_forever"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDeliveryPolicy(handlerWithDeliveryPolicy(tt.policy))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDeliveryPolicy(%v) error = %v, wantErr %v", tt.policy, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDeliveryPolicyNoBlock(t *testing.T) {
+	if err := validateDeliveryPolicy(map[string]interface{}{}); err != nil {
+		t.Errorf("unexpected error for handler with no delivery_policy: %v", err)
+	}
+}
+
+// get fires a real request at an httptest server through a resty client, since
+// resty.Response has no public constructor.
+func get(t *testing.T, status int, body string) (*resty.Response, error) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	return resty.New().R().Get(server.URL)
+}
+
+func TestRetryOnDeliveryError(t *testing.T) {
+	t.Run("5xx response", func(t *testing.T) {
+		resp, err := get(t, http.StatusBadGateway, "")
+		if err != nil {
+			t.Fatalf("unexpected request error: %v", err)
+		}
+		if !retryOnDeliveryError(resp, nil) {
+			t.Error("expected retry for a 5xx response")
+		}
+	})
+
+	t.Run("2xx response", func(t *testing.T) {
+		resp, err := get(t, http.StatusOK, "")
+		if err != nil {
+			t.Fatalf("unexpected request error: %v", err)
+		}
+		if retryOnDeliveryError(resp, nil) {
+			t.Error("expected no retry for a 2xx response")
+		}
+	})
+
+	t.Run("proxy not found", func(t *testing.T) {
+		resp, err := get(t, http.StatusBadRequest, "proxy with key 'my-proxy' not found")
+		if err != nil {
+			t.Fatalf("unexpected request error: %v", err)
+		}
+		if !retryOnDeliveryError(resp, nil) {
+			t.Error("expected retry when the proxy-not-found body is present")
+		}
+	})
+
+	t.Run("other 4xx", func(t *testing.T) {
+		resp, err := get(t, http.StatusBadRequest, "bad request")
+		if err != nil {
+			t.Fatalf("unexpected request error: %v", err)
+		}
+		if retryOnDeliveryError(resp, nil) {
+			t.Error("expected no retry for an unrelated 4xx response")
+		}
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		if !retryOnDeliveryError(nil, errReqFailed) {
+			t.Error("expected retry when the request itself failed")
+		}
+	})
+}
+
+func TestAggregateRetryPolicy(t *testing.T) {
+	handlers := []Handler{
+		{DeliveryPolicy: nil},
+		{DeliveryPolicy: &DeliveryPolicy{MaxRetries: 2, BackoffSeconds: 1}},
+		{DeliveryPolicy: &DeliveryPolicy{MaxRetries: 5, BackoffSeconds: 3}},
+	}
+
+	maxRetries, backoff := aggregateRetryPolicy(handlers)
+	if maxRetries != 5 {
+		t.Errorf("maxRetries = %d, want 5", maxRetries)
+	}
+	if backoff != 3*time.Second {
+		t.Errorf("backoff = %v, want 3s", backoff)
+	}
+}
+
+func TestAggregateRetryPolicyNoDeliveryPolicy(t *testing.T) {
+	maxRetries, backoff := aggregateRetryPolicy([]Handler{{}, {}})
+	if maxRetries != 0 || backoff != 0 {
+		t.Errorf("aggregateRetryPolicy() = (%d, %v), want (0, 0)", maxRetries, backoff)
+	}
+}
@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mergeSchemas combines several attribute maps into one, used to assemble a block's Elem
+// schema out of the pieces it's built from (e.g. patternSchema() + a domain's own criteria
+// fields).
+func mergeSchemas(maps ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// legacyHandlerFieldsSchema is the schema-v1 shape: a single handler's fields live at the
+// top level of the resource (see ResourceStateUpgradeV1, which moves them under a "handler"
+// block on upgrade).
+func legacyHandlerFieldsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"url": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "URL that jfrog should call when the underlying event occurs.",
+		},
+		"secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Secret authentication token sent to the configured URL.",
+		},
+		"proxy": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Proxy key, as configured in Artifactory's proxy settings, to route deliveries through.",
+		},
+		"custom_http_headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Custom HTTP headers to add to every delivery.",
+		},
+	}
+}
+
+// legacyHandlerSchema is the schema-v2 `handler` block shape, from before the email handler
+// type, event_types scoping, previous_secret rotation and delivery_policy were added.
+// resourceSchemaV2 (see ResourceArtifactoryWebhook) uses this to compute the ImpliedType the
+// V2->V3 StateUpgrader upgrades from.
+func legacyHandlerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"url": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "URL that jfrog should call when the underlying event occurs.",
+		},
+		"secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Secret authentication token sent to the configured URL, or used to sign the payload when use_secret_for_signing is enabled.",
+		},
+		"use_secret_for_signing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "When true, secret is used to sign the payload instead of only being passed in a header.",
+		},
+		"proxy": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Proxy key, as configured in Artifactory's proxy settings, to route deliveries through.",
+		},
+		"custom_http_headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Custom HTTP headers to add to every delivery.",
+		},
+	}
+}
+
+// domainWebhookSchema assembles the schema every `artifactory_*_webhook` resource shares
+// (key, description, enabled, event_types, criteria) and version-gates the handler/event_rule
+// blocks: v1 predates the "handler" block entirely (a single handler's fields sit at the top
+// level), v2 has a "handler" block but not yet handler_type/recipients/previous_secret/
+// event_types/delivery_policy, and the current version (3) is the first to embed
+// handlerSchema() and eventRuleSchema() so those attributes are actually configurable.
+// domainCriteriaFields is merged into both the "criteria" block and each "event_rule" block,
+// since both describe the same domain-specific criteria shape, just scoped differently.
+func domainWebhookSchema(webhookType string, version int, domainCriteriaFields map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Webhook key. Must be unique.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Webhook description.",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Status of the webhook. Default to `true`.",
+		},
+		"event_types": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("List of event types. Allowed values: %q.", DomainEventTypesSupported[webhookType]),
+		},
+		"criteria": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: mergeSchemas(patternSchema(), domainCriteriaFields)},
+			Description: "Specifies the criteria for matching events, scoped to every configured event_types. Mutually exclusive with event_rule: use event_rule instead to scope different criteria to different event types.",
+		},
+	}
+
+	if version == 1 {
+		return mergeSchemas(s, legacyHandlerFieldsSchema())
+	}
+
+	if version == 2 {
+		s["handler"] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Resource{Schema: legacyHandlerSchema()},
+			Description: "At least one is required.",
+		}
+		return s
+	}
+
+	s["handler"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Resource{Schema: handlerSchema()},
+		Description: "At least one is required.",
+	}
+	s["criteria"].ConflictsWith = []string{"event_rule"}
+	s["event_rule"] = &schema.Schema{
+		Type:          schema.TypeSet,
+		Optional:      true,
+		ConflictsWith: []string{"criteria"},
+		Elem:          &schema.Resource{Schema: mergeSchemas(eventRuleSchema(), patternSchema(), domainCriteriaFields)},
+		Description:   "Scopes criteria to a subset of event_types, so different event types can be matched against different criteria (and, via a handler's own event_types, routed to different handlers) within a single resource. Repeatable; mutually exclusive with criteria.",
+	}
+
+	return s
+}
+
+func repoWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, repoCriteriaFields())
+}
+
+func buildWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, buildCriteriaFields())
+}
+
+func releaseBundleWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, releaseBundleCriteriaFields())
+}
+
+func userWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, map[string]*schema.Schema{})
+}
+
+func releaseBundleV2WebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, releaseBundleV2CriteriaFields())
+}
+
+func releaseBundleV2PromotionWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, releaseBundleV2PromotionCriteriaFields())
+}
+
+func artifactLifecycleWebhookSchema(webhookType string, version int, isCustom bool) map[string]*schema.Schema {
+	return domainWebhookSchema(webhookType, version, map[string]*schema.Schema{})
+}
@@ -0,0 +1,171 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	utilsdk "github.com/jfrog/terraform-provider-shared/util/sdk"
+)
+
+// EventRule scopes a criteria block to a subset of a webhook's event types, so a single
+// `artifactory_*_webhook` resource can apply different criteria (and different handlers,
+// via Handler.EventTypes) to different event types instead of requiring one resource per
+// event type.
+type EventRule struct {
+	EventTypes []string    `json:"event_types"`
+	Criteria   interface{} `json:"criteria"`
+}
+
+// patternSchema returns the include/exclude pattern fields every domain's criteria shares;
+// both the `criteria` block and each `event_rule` block embed this alongside their
+// domain-specific fields.
+func patternSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"include_patterns": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Simple comma separated wildcard patterns for repository or build names. Ant-style path expressions are also supported.",
+		},
+		"exclude_patterns": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Simple comma separated wildcard patterns for repository or build names to exclude. Ant-style path expressions are also supported.",
+		},
+	}
+}
+
+// eventRuleSchema returns the event_types field of a single `event_rule` block.
+// `event_rule` is repeatable, so a webhook can scope different criteria (and, via a
+// handler's own `event_types`, different handlers) to different event types instead of
+// requiring one resource per event type. Each domain's schema builder merges this with
+// patternSchema() and its own domain-specific criteria fields to build the full `event_rule`
+// Elem, the same way it builds the `criteria` Elem.
+func eventRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"event_types": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Event types this rule applies to. Must be a subset of the webhook's overall event_types.",
+		},
+	}
+}
+
+// unpackEventRules reads the repeatable `event_rule` blocks off the resource. It returns nil
+// when none are configured, so a config using only the legacy singular `criteria` block
+// keeps flowing through unpackCriteria/packCriteria unchanged — EventFilter.EventRules must
+// stay empty for those configs, or packWebhook would write Artifactory's response into the
+// `event_rule` attribute instead of `criteria` on every read, producing a permanent plan
+// diff for a block the user never declared.
+var unpackEventRules = func(d *utilsdk.ResourceData, webhookType string) []EventRule {
+	v, ok := d.GetOk("event_rule")
+	if !ok {
+		return nil
+	}
+
+	rules := v.(*schema.Set).List()
+	eventRules := make([]EventRule, 0, len(rules))
+	for _, rule := range rules {
+		r := rule.(map[string]interface{})
+
+		baseCriteria := BaseWebhookCriteria{
+			IncludePatterns: utilsdk.CastToStringArr(r["include_patterns"].(*schema.Set).List()),
+			ExcludePatterns: utilsdk.CastToStringArr(r["exclude_patterns"].(*schema.Set).List()),
+		}
+
+		eventRules = append(eventRules, EventRule{
+			EventTypes: utilsdk.CastToStringArr(r["event_types"].(*schema.Set).List()),
+			Criteria:   domainUnpackLookup[webhookType](r, baseCriteria),
+		})
+	}
+
+	return eventRules
+}
+
+// packEventRules writes the event rules Artifactory returns into the `event_rule` attribute,
+// one block per returned rule whose criteria is a JSON object. It does not re-group a flat
+// `criteria` response into rules, and does not match rules against the configured blocks.
+var packEventRules = func(d *schema.ResourceData, webhookType string, eventRules []EventRule) []error {
+	setValue := utilsdk.MkLens(d)
+
+	if len(eventRules) == 0 {
+		return nil
+	}
+
+	resource := domainSchemaLookup(currentSchemaVersion, false, webhookType)[webhookType]["event_rule"].Elem.(*schema.Resource)
+
+	var packedRules []interface{}
+	var errors []error
+	for _, rule := range eventRules {
+		if rule.Criteria == nil {
+			continue
+		}
+
+		criteria, ok := rule.Criteria.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		packedCriteria := domainPackLookup[webhookType](criteria)
+
+		includePatterns := []interface{}{}
+		if v, ok := criteria["includePatterns"]; ok && v != nil {
+			includePatterns = v.([]interface{})
+		}
+		packedCriteria["include_patterns"] = schema.NewSet(schema.HashString, includePatterns)
+
+		excludePatterns := []interface{}{}
+		if v, ok := criteria["excludePatterns"]; ok && v != nil {
+			excludePatterns = v.([]interface{})
+		}
+		packedCriteria["exclude_patterns"] = schema.NewSet(schema.HashString, excludePatterns)
+
+		packedCriteria["event_types"] = schema.NewSet(schema.HashString, utilsdk.CastToInterfaceArr(rule.EventTypes))
+
+		packedRules = append(packedRules, packedCriteria)
+	}
+
+	errors = append(errors, setValue("event_rule", schema.NewSet(schema.HashResource(resource), packedRules))...)
+
+	return errors
+}
+
+// eventRuleDiff validates that every event_rule's event_types is a subset of the webhook's
+// overall event_types, and that its criteria is valid for the domain.
+var eventRuleDiff = func(ctx context.Context, webhookType string, diff *schema.ResourceDiff) error {
+	rulesRaw, ok := diff.GetOk("event_rule")
+	if !ok {
+		return nil
+	}
+
+	eventTypes := diff.Get("event_types").(*schema.Set).List()
+	supported := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		supported[eventType.(string)] = true
+	}
+
+	rules := rulesRaw.(*schema.Set).List()
+	for _, rule := range rules {
+		r := rule.(map[string]interface{})
+
+		ruleEventTypes := r["event_types"].(*schema.Set).List()
+		if len(ruleEventTypes) == 0 {
+			return fmt.Errorf("event_rule requires at least one event type in event_types")
+		}
+
+		for _, eventType := range ruleEventTypes {
+			if !supported[eventType.(string)] {
+				return fmt.Errorf("event_rule event_types value %q is not present in the webhook's event_types", eventType)
+			}
+		}
+
+		if err := domainCriteriaValidationLookup[webhookType](ctx, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	utilsdk "github.com/jfrog/terraform-provider-shared/util/sdk"
+)
+
+// TestUnpackEventRulesNoEventRuleBlock guards against a regression where unpackEventRules
+// synthesized a one-element EventRules slice from the legacy criteria/event_types pair
+// whenever no event_rule block was configured. That made EventFilter.EventRules non-empty
+// for every plain `criteria` config, and packWebhook's len(EventRules) > 0 branch would then
+// write the server's response into the event_rule attribute instead of criteria on every
+// read, producing a permanent plan diff for users who never declared event_rule.
+func TestUnpackEventRulesNoEventRuleBlock(t *testing.T) {
+	r := ResourceArtifactoryWebhook("artifact")
+
+	raw := map[string]interface{}{
+		"key":         "test-webhook",
+		"event_types": []interface{}{"deployed"},
+		"criteria": []interface{}{
+			map[string]interface{}{
+				"any_local": true,
+			},
+		},
+	}
+
+	d := &utilsdk.ResourceData{ResourceData: schema.TestResourceDataRaw(t, r.Schema, raw)}
+
+	if got := unpackEventRules(d, "artifact"); got != nil {
+		t.Errorf("unpackEventRules = %v, want nil when no event_rule block is configured", got)
+	}
+}
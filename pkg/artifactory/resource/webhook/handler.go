@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	utilsdk "github.com/jfrog/terraform-provider-shared/util/sdk"
+)
+
+const (
+	HandlerTypeWebhook = "webhook"
+	HandlerTypeEmail   = "email"
+)
+
+// HandlerTypesSupported lists the handler_type values accepted in a `handler` block.
+var HandlerTypesSupported = []string{
+	HandlerTypeWebhook,
+	HandlerTypeEmail,
+}
+
+// handlerUnpackLookup mirrors domainCriteriaLookup: one unpack function per handler_type
+// so new delivery targets (Slack, PagerDuty, ...) can be added without expanding an if/else chain.
+var handlerUnpackLookup = map[string]func(map[string]interface{}) Handler{
+	HandlerTypeWebhook: unpackWebhookHandler,
+	HandlerTypeEmail:   unpackEmailHandler,
+}
+
+var handlerPackLookup = map[string]func(Handler) map[string]interface{}{
+	HandlerTypeWebhook: packWebhookHandler,
+	HandlerTypeEmail:   packEmailHandler,
+}
+
+var handlerValidationLookup = map[string]func(map[string]interface{}) error{
+	HandlerTypeWebhook: validateWebhookHandler,
+	HandlerTypeEmail:   validateEmailHandler,
+}
+
+func unpackWebhookHandler(h map[string]interface{}) Handler {
+	handler := Handler{
+		HandlerType: HandlerTypeWebhook,
+		Url:         h["url"].(string),
+	}
+
+	if v, ok := h["secret"]; ok {
+		handler.Secret = v.(string)
+	}
+
+	if v, ok := h["previous_secret"]; ok {
+		handler.PreviousSecret = v.(string)
+	}
+
+	if v, ok := h["use_secret_for_signing"]; ok {
+		handler.UseSecretForSigning = v.(bool)
+	}
+
+	if v, ok := h["proxy"]; ok {
+		handler.Proxy = v.(string)
+	}
+
+	if v, ok := h["custom_http_headers"]; ok {
+		handler.CustomHttpHeaders = unpackKeyValuePair(v.(map[string]interface{}))
+	}
+
+	return handler
+}
+
+func packWebhookHandler(handler Handler) map[string]interface{} {
+	packedHandler := map[string]interface{}{
+		"handler_type":           HandlerTypeWebhook,
+		"url":                    handler.Url,
+		"use_secret_for_signing": handler.UseSecretForSigning,
+		"proxy":                  handler.Proxy,
+	}
+
+	if handler.CustomHttpHeaders != nil {
+		packedHandler["custom_http_headers"] = packKeyValuePair(handler.CustomHttpHeaders)
+	}
+
+	return packedHandler
+}
+
+func validateWebhookHandler(h map[string]interface{}) error {
+	if url, ok := h["url"].(string); !ok || url == "" {
+		return fmt.Errorf("url is required when handler_type is %q", HandlerTypeWebhook)
+	}
+
+	return nil
+}
+
+func unpackEmailHandler(h map[string]interface{}) Handler {
+	handler := Handler{
+		HandlerType: HandlerTypeEmail,
+	}
+
+	if v, ok := h["recipients"]; ok {
+		handler.Recipients = utilsdk.CastToStringArr(v.(*schema.Set).List())
+	}
+
+	return handler
+}
+
+func packEmailHandler(handler Handler) map[string]interface{} {
+	return map[string]interface{}{
+		"handler_type": HandlerTypeEmail,
+		"recipients":   schema.NewSet(schema.HashString, utilsdk.CastToInterfaceArr(handler.Recipients)),
+	}
+}
+
+func validateEmailHandler(h map[string]interface{}) error {
+	recipients, ok := h["recipients"].(*schema.Set)
+	if !ok || recipients.Len() == 0 {
+		return fmt.Errorf("recipients is required when handler_type is %q", HandlerTypeEmail)
+	}
+
+	return nil
+}
+
+// handlerSchema defines the attributes of a single `handler` block. Unlike `criteria`, a
+// handler's shape doesn't vary by webhook domain, so every domain's schema builder embeds
+// this same map as the `handler` attribute's Elem.
+func handlerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"handler_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      HandlerTypeWebhook,
+			ValidateFunc: validation.StringInSlice(HandlerTypesSupported, false),
+			Description:  fmt.Sprintf("Type of handler that receives this webhook's events. One of %q. Defaults to `webhook`.", HandlerTypesSupported),
+		},
+		"url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "URL that jfrog should call when the underlying event occurs. Required when `handler_type` is `webhook`.",
+		},
+		"secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Secret authentication token sent to the configured URL, or used to sign the payload when `use_secret_for_signing` is enabled.",
+		},
+		"previous_secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The secret being rotated out. When set alongside `secret`, Artifactory accepts deliveries signed with either value so in-flight deliveries aren't rejected mid-rotation.",
+		},
+		"use_secret_for_signing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "When `true`, `secret` is used to sign the payload instead of only being passed in a header.",
+		},
+		"proxy": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Proxy key, as configured in Artifactory's proxy settings, to route deliveries through.",
+		},
+		"custom_http_headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Custom HTTP headers to add to every delivery.",
+		},
+		"recipients": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Email addresses to notify. Required when `handler_type` is `email`.",
+		},
+		"event_types": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Scopes this handler to a subset of the webhook's event_types. Defaults to every configured event type.",
+		},
+		"delivery_policy": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: deliveryPolicySchema()},
+			Description: "Per-handler retry/timeout/failure-policy for deliveries.",
+		},
+	}
+}
+
+var handlerResource = &schema.Resource{Schema: handlerSchema()}
+
+// handlerType returns the handler_type for a handler block, defaulting to the
+// original (and only, pre schema-v3) webhook handler type when unset.
+func handlerType(h map[string]interface{}) string {
+	if v, ok := h["handler_type"]; ok {
+		if t, ok := v.(string); ok && t != "" {
+			return t
+		}
+	}
+
+	return HandlerTypeWebhook
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestHandlerType(t *testing.T) {
+	tests := []struct {
+		name string
+		h    map[string]interface{}
+		want string
+	}{
+		{"explicit webhook", map[string]interface{}{"handler_type": "webhook"}, HandlerTypeWebhook},
+		{"explicit email", map[string]interface{}{"handler_type": "email"}, HandlerTypeEmail},
+		{"unset defaults to webhook", map[string]interface{}{}, HandlerTypeWebhook},
+		{"empty string defaults to webhook", map[string]interface{}{"handler_type": ""}, HandlerTypeWebhook},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := handlerType(tt.h); got != tt.want {
+				t.Errorf("handlerType(%v) = %q, want %q", tt.h, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookHandler(t *testing.T) {
+	if err := validateWebhookHandler(map[string]interface{}{"url": "https://example.com"}); err != nil {
+		t.Errorf("unexpected error for handler with url: %v", err)
+	}
+
+	if err := validateWebhookHandler(map[string]interface{}{"url": ""}); err == nil {
+		t.Error("expected error for handler with empty url, got nil")
+	}
+}
+
+func TestValidateEmailHandler(t *testing.T) {
+	withRecipients := map[string]interface{}{
+		"recipients": schema.NewSet(schema.HashString, []interface{}{"a@example.com"}),
+	}
+	if err := validateEmailHandler(withRecipients); err != nil {
+		t.Errorf("unexpected error for handler with recipients: %v", err)
+	}
+
+	withoutRecipients := map[string]interface{}{
+		"recipients": schema.NewSet(schema.HashString, []interface{}{}),
+	}
+	if err := validateEmailHandler(withoutRecipients); err == nil {
+		t.Error("expected error for handler with no recipients, got nil")
+	}
+}
+
+func TestEmailHandlerPackUnpackRoundTrip(t *testing.T) {
+	handler := Handler{
+		HandlerType: HandlerTypeEmail,
+		Recipients:  []string{"a@example.com", "b@example.com"},
+	}
+
+	packed := packEmailHandler(handler)
+	unpacked := unpackEmailHandler(packed)
+
+	if unpacked.HandlerType != HandlerTypeEmail {
+		t.Errorf("HandlerType = %q, want %q", unpacked.HandlerType, HandlerTypeEmail)
+	}
+
+	got := schema.NewSet(schema.HashString, []interface{}{})
+	for _, r := range unpacked.Recipients {
+		got.Add(r)
+	}
+	want := schema.NewSet(schema.HashString, []interface{}{"a@example.com", "b@example.com"})
+	if !got.Equal(want) {
+		t.Errorf("Recipients = %v, want %v", unpacked.Recipients, handler.Recipients)
+	}
+}
@@ -64,15 +64,31 @@ type EventFilter struct {
 	Domain     string      `json:"domain"`
 	EventTypes []string    `json:"event_types"`
 	Criteria   interface{} `json:"criteria"`
+	// EventRules scopes Criteria to a subset of EventTypes, letting a single subscription
+	// apply different criteria per event type instead of requiring one resource per rule.
+	// It takes precedence over Criteria when non-empty.
+	EventRules []EventRule `json:"event_rules,omitempty"`
 }
 
 type Handler struct {
-	HandlerType         string         `json:"handler_type"`
-	Url                 string         `json:"url"`
-	Secret              string         `json:"secret"`
-	UseSecretForSigning bool           `json:"use_secret_for_signing"`
-	Proxy               string         `json:"proxy"`
-	CustomHttpHeaders   []KeyValuePair `json:"custom_http_headers"`
+	HandlerType string `json:"handler_type"`
+	Url         string `json:"url,omitempty"`
+	Secret      string `json:"secret,omitempty"`
+	// PreviousSecret, when set, is submitted alongside Secret so Artifactory accepts
+	// deliveries signed with either secret during a rotation window, avoiding a window
+	// where in-flight deliveries signed with the old secret are rejected.
+	PreviousSecret      string         `json:"previous_secret,omitempty"`
+	UseSecretForSigning bool           `json:"use_secret_for_signing,omitempty"`
+	Proxy               string         `json:"proxy,omitempty"`
+	CustomHttpHeaders   []KeyValuePair `json:"custom_http_headers,omitempty"`
+	Recipients          []string       `json:"recipients,omitempty"`
+	// EventTypes, when non-empty, scopes this handler to a subset of the webhook's
+	// event types instead of receiving every configured event.
+	EventTypes []string `json:"event_types,omitempty"`
+	// DeliveryPolicy codifies this handler's retry/timeout/failure-policy SLO. It's
+	// forwarded to Artifactory as-is where the subscription API accepts it, and also
+	// drives the resty retry chain and post-apply verification in this package.
+	DeliveryPolicy *DeliveryPolicy `json:"delivery_policy,omitempty"`
 }
 
 type KeyValuePair struct {
@@ -84,7 +100,15 @@ const webhooksUrl = "/event/api/v1/subscriptions"
 
 const WhUrl = webhooksUrl + "/{webhookKey}"
 
-const currentSchemaVersion = 2
+const currentSchemaVersion = 3
+
+var proxyNotFoundRegex = regexp.MustCompile("proxy with key '.*' not found")
+
+// retryOnProxyError is shared with the webhook test resource so a misconfigured proxy
+// doesn't surface as a flaky delivery failure on either resource.
+var retryOnProxyError = func(response *resty.Response, _r error) bool {
+	return proxyNotFoundRegex.MatchString(string(response.Body()[:]))
+}
 
 var unpackKeyValuePair = func(keyValuePairs map[string]interface{}) []KeyValuePair {
 	var kvPairs []KeyValuePair
@@ -153,6 +177,12 @@ var domainUnpackLookup = map[string]func(map[string]interface{}, BaseWebhookCrit
 	"artifact_lifecycle":          unpackEmptyCriteria,
 }
 
+// domainSchemaLookup returns the per-domain resource schema, keyed by attribute name
+// ("handler", "criteria", "event_rule", ...). Each <domain>WebhookSchema builder (see
+// domain_schema.go) calls domainWebhookSchema, which embeds handlerSchema() and
+// eventRuleSchema() (handler.go, event_rule.go) as the Elem for its "handler" and
+// "event_rule" attributes from schema version 3 on — that's what makes handler_type,
+// recipients, previous_secret, delivery_policy and event_rule actually configurable.
 var domainSchemaLookup = func(version int, isCustom bool, webhookType string) map[string]map[string]*schema.Schema {
 	return map[string]map[string]*schema.Schema{
 		"artifact":                    repoWebhookSchema(webhookType, version, isCustom),
@@ -230,21 +260,64 @@ var emptyCriteriaValidation = func(ctx context.Context, criteria map[string]inte
 	return nil
 }
 
+// verifyDelivery fires a test event at the subscription and fails apply on a non-2xx
+// response, for any handler whose delivery_policy declares failure_policy = "fail" —
+// Artifactory's create/update call only validates the subscription shape, not that the
+// configured handlers are actually reachable.
+var verifyDelivery = func(ctx context.Context, m interface{}, webhook BaseParams) diag.Diagnostics {
+	requiresVerification := false
+	for _, handler := range webhook.Handlers {
+		if handler.DeliveryPolicy != nil && handler.DeliveryPolicy.FailurePolicy == FailurePolicyFail {
+			requiresVerification = true
+			break
+		}
+	}
+	if !requiresVerification {
+		return nil
+	}
+
+	var artifactoryError artifactory.ArtifactoryErrorsResponse
+	resp, err := m.(util.ProviderMetadata).Client.R().
+		SetContext(ctx).
+		SetPathParam("webhookKey", webhook.Id()).
+		AddRetryCondition(retryOnDeliveryError).
+		SetError(&artifactoryError).
+		Post(webhookTestUrl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if resp.IsError() {
+		return diag.Errorf("webhook %q has a handler with failure_policy \"fail\", and the post-apply test delivery failed: %s", webhook.Id(), artifactoryError.String())
+	}
+
+	return nil
+}
+
 var packSecret = func(d *schema.ResourceData, url string) string {
-	// Get secret from TF state
-	var secret string
+	secret, _ := packSecrets(d, url)
+	return secret
+}
+
+// packSecrets restores secret and previous_secret from TF state, keyed by url, since
+// Artifactory never returns either value back to us on read.
+var packSecrets = func(d *schema.ResourceData, url string) (string, string) {
+	var secret, previousSecret string
 	if v, ok := d.GetOk("handler"); ok {
 		handlers := v.(*schema.Set).List()
 		for _, handler := range handlers {
 			h := handler.(map[string]interface{})
-			// if urls match, assign the secret value from the state
+			// if urls match, assign the secret values from the state
 			if h["url"].(string) == url {
 				secret = h["secret"].(string)
+				if v, ok := h["previous_secret"]; ok {
+					previousSecret = v.(string)
+				}
 			}
 		}
 	}
 
-	return secret
+	return secret, previousSecret
 }
 
 func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
@@ -259,32 +332,26 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 				handlers := v.(*schema.Set).List()
 				for _, handler := range handlers {
 					h := handler.(map[string]interface{})
+					handlerType := handlerType(h)
+
 					// use this to filter out weirdness with terraform adding an extra blank webhook in a set
 					// https://discuss.hashicorp.com/t/using-typeset-in-provider-always-adds-an-empty-element-on-update/18566/2
-					if h["url"].(string) != "" {
-						webhookHandler := Handler{
-							HandlerType: "webhook",
-							Url:         h["url"].(string),
-						}
-
-						if v, ok := h["secret"]; ok {
-							webhookHandler.Secret = v.(string)
-						}
-
-						if v, ok := h["use_secret_for_signing"]; ok {
-							webhookHandler.UseSecretForSigning = v.(bool)
-						}
-
-						if v, ok := h["proxy"]; ok {
-							webhookHandler.Proxy = v.(string)
-						}
+					if handlerType == HandlerTypeWebhook && h["url"].(string) == "" {
+						continue
+					}
 
-						if v, ok := h["custom_http_headers"]; ok {
-							webhookHandler.CustomHttpHeaders = unpackKeyValuePair(v.(map[string]interface{}))
-						}
+					unpack, ok := handlerUnpackLookup[handlerType]
+					if !ok {
+						continue
+					}
 
-						webhookHandlers = append(webhookHandlers, webhookHandler)
+					webhookHandler := unpack(h)
+					if v, ok := h["event_types"]; ok {
+						webhookHandler.EventTypes = utilsdk.CastToStringArr(v.(*schema.Set).List())
 					}
+					webhookHandler.DeliveryPolicy = unpackDeliveryPolicy(h)
+
+					webhookHandlers = append(webhookHandlers, webhookHandler)
 				}
 			}
 
@@ -299,6 +366,7 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 				Domain:     webhookType,
 				EventTypes: d.GetSet("event_types"),
 				Criteria:   unpackCriteria(d, webhookType),
+				EventRules: unpackEventRules(d, webhookType),
 			},
 			Handlers: unpackHandlers(d),
 		}
@@ -308,24 +376,26 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 
 	var packHandlers = func(d *schema.ResourceData, handlers []Handler) []error {
 		setValue := utilsdk.MkLens(d)
-		resource := domainSchemaLookup(currentSchemaVersion, false, webhookType)[webhookType]["handler"].Elem.(*schema.Resource)
 		var packedHandlers []interface{}
 		for _, handler := range handlers {
-			packedHandler := map[string]interface{}{
-				"url":                    handler.Url,
-				"secret":                 packSecret(d, handler.Url),
-				"use_secret_for_signing": handler.UseSecretForSigning,
-				"proxy":                  handler.Proxy,
+			pack, ok := handlerPackLookup[handler.HandlerType]
+			if !ok {
+				continue
 			}
 
-			if handler.CustomHttpHeaders != nil {
-				packedHandler["custom_http_headers"] = packKeyValuePair(handler.CustomHttpHeaders)
+			packedHandler := pack(handler)
+			if handler.HandlerType == HandlerTypeWebhook {
+				secret, previousSecret := packSecrets(d, handler.Url)
+				packedHandler["secret"] = secret
+				packedHandler["previous_secret"] = previousSecret
 			}
+			packedHandler["event_types"] = schema.NewSet(schema.HashString, utilsdk.CastToInterfaceArr(handler.EventTypes))
+			packedHandler["delivery_policy"] = schema.NewSet(schema.HashResource(deliveryPolicyResource), packDeliveryPolicy(handler.DeliveryPolicy))
 
 			packedHandlers = append(packedHandlers, packedHandler)
 		}
 
-		return setValue("handler", schema.NewSet(schema.HashResource(resource), packedHandlers))
+		return setValue("handler", schema.NewSet(schema.HashResource(handlerResource), packedHandlers))
 	}
 
 	var packWebhook = func(d *schema.ResourceData, webhook BaseParams) diag.Diagnostics {
@@ -335,7 +405,9 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		setValue("description", webhook.Description)
 		setValue("enabled", webhook.Enabled)
 		errors := setValue("event_types", webhook.EventFilter.EventTypes)
-		if webhook.EventFilter.Criteria != nil {
+		if len(webhook.EventFilter.EventRules) > 0 {
+			errors = append(errors, packEventRules(d, webhookType, webhook.EventFilter.EventRules)...)
+		} else if webhook.EventFilter.Criteria != nil {
 			errors = append(errors, packCriteria(d, webhookType, webhook.EventFilter.Criteria.(map[string]interface{}))...)
 		}
 		errors = append(errors, packHandlers(d, webhook.Handlers)...)
@@ -377,12 +449,6 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		return packWebhook(data, webhook)
 	}
 
-	var retryOnProxyError = func(response *resty.Response, _r error) bool {
-		var proxyNotFoundRegex = regexp.MustCompile("proxy with key '.*' not found")
-
-		return proxyNotFoundRegex.MatchString(string(response.Body()[:]))
-	}
-
 	var createWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		tflog.Debug(ctx, "createWebhook")
 
@@ -391,12 +457,24 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 			return diag.FromErr(err)
 		}
 
+		// Only broaden the retry condition to cover 5xx/connection errors for webhooks that
+		// actually declared a delivery_policy; everyone else keeps the narrower
+		// proxy-not-found-only behavior they already had.
+		retryCondition := retryOnProxyError
+		if anyHandlerHasDeliveryPolicy(webhook.Handlers) {
+			retryCondition = retryOnDeliveryError
+		}
+		maxRetries, backoff := aggregateRetryPolicy(webhook.Handlers)
+
 		var artifactoryError artifactory.ArtifactoryErrorsResponse
-		resp, err := m.(util.ProviderMetadata).Client.R().
+		req := m.(util.ProviderMetadata).Client.R().
 			SetBody(webhook).
-			AddRetryCondition(retryOnProxyError).
-			SetError(&artifactoryError).
-			Post(webhooksUrl)
+			AddRetryCondition(retryCondition).
+			SetError(&artifactoryError)
+		if maxRetries > 0 {
+			req = req.SetRetryCount(maxRetries).SetRetryWaitTime(backoff)
+		}
+		resp, err := req.Post(webhooksUrl)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -407,6 +485,10 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 
 		data.SetId(webhook.Id())
 
+		if diags := verifyDelivery(ctx, m, webhook); diags.HasError() {
+			return diags
+		}
+
 		return readWebhook(ctx, data, m)
 	}
 
@@ -418,13 +500,22 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 			return diag.FromErr(err)
 		}
 
+		retryCondition := retryOnProxyError
+		if anyHandlerHasDeliveryPolicy(webhook.Handlers) {
+			retryCondition = retryOnDeliveryError
+		}
+		maxRetries, backoff := aggregateRetryPolicy(webhook.Handlers)
+
 		var artifactoryError artifactory.ArtifactoryErrorsResponse
-		resp, err := m.(util.ProviderMetadata).Client.R().
+		req := m.(util.ProviderMetadata).Client.R().
 			SetPathParam("webhookKey", data.Id()).
 			SetBody(webhook).
-			AddRetryCondition(retryOnProxyError).
-			SetError(&artifactoryError).
-			Put(WhUrl)
+			AddRetryCondition(retryCondition).
+			SetError(&artifactoryError)
+		if maxRetries > 0 {
+			req = req.SetRetryCount(maxRetries).SetRetryWaitTime(backoff)
+		}
+		resp, err := req.Put(WhUrl)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -435,6 +526,10 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 
 		data.SetId(webhook.Id())
 
+		if diags := verifyDelivery(ctx, m, webhook); diags.HasError() {
+			return diags
+		}
+
 		return readWebhook(ctx, data, m)
 	}
 
@@ -480,6 +575,31 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		return nil
 	}
 
+	var handlerDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+		tflog.Debug(ctx, "handlerDiff")
+
+		if resource, ok := diff.GetOk("handler"); ok {
+			handlers := resource.(*schema.Set).List()
+			for _, handler := range handlers {
+				h := handler.(map[string]interface{})
+				validate, ok := handlerValidationLookup[handlerType(h)]
+				if !ok {
+					return fmt.Errorf("handler_type %s not supported", handlerType(h))
+				}
+
+				if err := validate(h); err != nil {
+					return err
+				}
+
+				if err := validateDeliveryPolicy(h); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
 	var criteriaDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 		tflog.Debug(ctx, "criteriaDiff")
 
@@ -494,14 +614,18 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		return nil
 	}
 
-	// Previous version of the schema
+	// Previous versions of the schema
 	// see example in https://www.terraform.io/plugin/sdkv2/resources/state-migration#terraform-v0-12-sdk-state-migrations
 	resourceSchemaV1 := &schema.Resource{
 		Schema: domainSchemaLookup(1, false, webhookType)[webhookType],
 	}
 
+	resourceSchemaV2 := &schema.Resource{
+		Schema: domainSchemaLookup(2, false, webhookType)[webhookType],
+	}
+
 	rs := schema.Resource{
-		SchemaVersion: 2,
+		SchemaVersion: currentSchemaVersion,
 		CreateContext: createWebhook,
 		ReadContext:   readWebhook,
 		UpdateContext: updateWebhook,
@@ -518,11 +642,20 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 				Upgrade: ResourceStateUpgradeV1,
 				Version: 1,
 			},
+			{
+				Type:    resourceSchemaV2.CoreConfigSchema().ImpliedType(),
+				Upgrade: ResourceStateUpgradeV2,
+				Version: 2,
+			},
 		},
 
 		CustomizeDiff: customdiff.All(
 			eventTypesDiff,
 			criteriaDiff,
+			handlerDiff,
+			func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return eventRuleDiff(ctx, webhookType, diff)
+			},
 		),
 		Description: "Provides an Artifactory webhook resource",
 	}
@@ -534,8 +667,9 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 	return &rs
 }
 
-// ResourceStateUpgradeV1 see the corresponding unit test TestWebhookResourceStateUpgradeV1
-// for more details on the schema transformation
+// ResourceStateUpgradeV1 moves the pre-"handler" top-level url/secret/proxy/custom_http_headers
+// fields into a single-element "handler" block. See TestWebhookResourceStateUpgradeV1 for the
+// schema transformation this performs.
 func ResourceStateUpgradeV1(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
 	rawState["handler"] = []map[string]interface{}{
 		{
@@ -553,3 +687,23 @@ func ResourceStateUpgradeV1(_ context.Context, rawState map[string]interface{},
 
 	return rawState, nil
 }
+
+// ResourceStateUpgradeV2 backfills handler_type on existing handler blocks, all of which
+// were implicitly "webhook" handlers before the email handler type was introduced.
+func ResourceStateUpgradeV2(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	handlers, ok := rawState["handler"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	for _, handler := range handlers {
+		h, ok := handler.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		h["handler_type"] = HandlerTypeWebhook
+	}
+
+	return rawState, nil
+}
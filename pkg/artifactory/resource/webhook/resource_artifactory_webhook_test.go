@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestDomainSchemaEmbedsHandlerAndEventRule guards against a repeat of the gap this series
+// shipped with: handlerSchema()/eventRuleSchema() being defined but never actually wired
+// into a domain's Terraform-visible schema, leaving handler_type, recipients,
+// previous_secret, delivery_policy and event_rule unconfigurable.
+func TestDomainSchemaEmbedsHandlerAndEventRule(t *testing.T) {
+	for _, webhookType := range TypesSupported {
+		s := domainSchemaLookup(currentSchemaVersion, false, webhookType)[webhookType]
+
+		handler, ok := s["handler"].Elem.(*schema.Resource)
+		if !ok {
+			t.Fatalf("%s: handler.Elem is not a *schema.Resource", webhookType)
+		}
+		for _, attr := range []string{"handler_type", "recipients", "previous_secret", "delivery_policy"} {
+			if _, ok := handler.Schema[attr]; !ok {
+				t.Errorf("%s: handler schema is missing %q", webhookType, attr)
+			}
+		}
+
+		eventRule, ok := s["event_rule"].Elem.(*schema.Resource)
+		if !ok {
+			t.Fatalf("%s: event_rule.Elem is not a *schema.Resource", webhookType)
+		}
+		if _, ok := eventRule.Schema["event_types"]; !ok {
+			t.Errorf("%s: event_rule schema is missing event_types", webhookType)
+		}
+	}
+}
+
+// TestEmailHandlerConfigurableThroughResourceSchema exercises the actual resource schema
+// (not just domainSchemaLookup's raw map) with schema.TestResourceDataRaw, to confirm a user
+// can declare an `email` handler_type with recipients end to end now that the handler block
+// embeds handlerSchema().
+func TestEmailHandlerConfigurableThroughResourceSchema(t *testing.T) {
+	r := ResourceArtifactoryWebhook("artifact")
+
+	raw := map[string]interface{}{
+		"key":         "test-webhook",
+		"event_types": []interface{}{"deployed"},
+		"handler": []interface{}{
+			map[string]interface{}{
+				"handler_type": HandlerTypeEmail,
+				"recipients":   []interface{}{"a@example.com"},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	v, ok := d.GetOk("handler")
+	if !ok {
+		t.Fatal("handler was not set on the resource data")
+	}
+
+	handlers := v.(*schema.Set).List()
+	if len(handlers) != 1 {
+		t.Fatalf("handler = %v, want a single-element set", handlers)
+	}
+
+	h := handlers[0].(map[string]interface{})
+	if h["handler_type"] != HandlerTypeEmail {
+		t.Errorf("handler_type = %v, want %q", h["handler_type"], HandlerTypeEmail)
+	}
+
+	recipients := h["recipients"].(*schema.Set).List()
+	if len(recipients) != 1 || recipients[0] != "a@example.com" {
+		t.Errorf("recipients = %v, want [a@example.com]", recipients)
+	}
+}
+
+// TestPreviousSecretConfigurableThroughResourceSchema confirms previous_secret — needed for
+// the dual-secret rotation in unpackWebhookHandler/packHandlers — is actually reachable from
+// a user's config now that the handler block embeds handlerSchema().
+func TestPreviousSecretConfigurableThroughResourceSchema(t *testing.T) {
+	r := ResourceArtifactoryWebhook("artifact")
+
+	raw := map[string]interface{}{
+		"key":         "test-webhook",
+		"event_types": []interface{}{"deployed"},
+		"handler": []interface{}{
+			map[string]interface{}{
+				"url":             "https://example.com",
+				"secret":          "new-secret",
+				"previous_secret": "old-secret",
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	v, ok := d.GetOk("handler")
+	if !ok {
+		t.Fatal("handler was not set on the resource data")
+	}
+
+	h := v.(*schema.Set).List()[0].(map[string]interface{})
+	if h["previous_secret"] != "old-secret" {
+		t.Errorf("previous_secret = %v, want %q", h["previous_secret"], "old-secret")
+	}
+}
+
+// TestResourceSchemaInternalValidate exercises the SDK's own schema validation (catches
+// things like a Required attribute also declaring a Default) across every domain. It
+// validates the current schema and the StateUpgraders chain; the v1/v2 schemas the
+// upgraders start from are not validated themselves.
+func TestResourceSchemaInternalValidate(t *testing.T) {
+	for _, webhookType := range TypesSupported {
+		r := ResourceArtifactoryWebhook(webhookType)
+		if err := r.InternalValidate(nil, true); err != nil {
+			t.Errorf("%s: InternalValidate failed: %v", webhookType, err)
+		}
+	}
+}
+
+// TestWebhookResourceStateUpgradeV1 checks that the pre-"handler" top-level url/secret/
+// proxy/custom_http_headers fields are moved into a single-element "handler" block, and
+// removed from the top level.
+func TestWebhookResourceStateUpgradeV1(t *testing.T) {
+	rawState := map[string]interface{}{
+		"key":                 "test-webhook",
+		"url":                 "https://example.com",
+		"secret":              "s3cr3t",
+		"proxy":               "my-proxy",
+		"custom_http_headers": map[string]interface{}{"X-Foo": "bar"},
+	}
+
+	upgraded, err := ResourceStateUpgradeV1(nil, rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"url", "secret", "proxy", "custom_http_headers"} {
+		if _, ok := upgraded[key]; ok {
+			t.Errorf("top-level %q should have been removed, got %v", key, upgraded[key])
+		}
+	}
+
+	handlers, ok := upgraded["handler"].([]map[string]interface{})
+	if !ok || len(handlers) != 1 {
+		t.Fatalf("handler = %v, want a single-element slice", upgraded["handler"])
+	}
+
+	h := handlers[0]
+	if h["url"] != "https://example.com" {
+		t.Errorf("handler[0].url = %v, want %q", h["url"], "https://example.com")
+	}
+	if h["secret"] != "s3cr3t" {
+		t.Errorf("handler[0].secret = %v, want %q", h["secret"], "s3cr3t")
+	}
+	if h["proxy"] != "my-proxy" {
+		t.Errorf("handler[0].proxy = %v, want %q", h["proxy"], "my-proxy")
+	}
+}
+
+// TestWebhookResourceStateUpgradeV2 mirrors TestWebhookResourceStateUpgradeV1: it checks
+// that existing handler blocks (all implicitly "webhook" handlers before the email handler
+// type was introduced) get handler_type backfilled on upgrade.
+func TestWebhookResourceStateUpgradeV2(t *testing.T) {
+	rawState := map[string]interface{}{
+		"key": "test-webhook",
+		"handler": []interface{}{
+			map[string]interface{}{
+				"url": "https://example.com",
+			},
+		},
+	}
+
+	upgraded, err := ResourceStateUpgradeV2(nil, rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handlers, ok := upgraded["handler"].([]interface{})
+	if !ok || len(handlers) != 1 {
+		t.Fatalf("handler = %v, want a single-element slice", upgraded["handler"])
+	}
+
+	h, ok := handlers[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("handler[0] = %v, want map[string]interface{}", handlers[0])
+	}
+
+	if h["handler_type"] != HandlerTypeWebhook {
+		t.Errorf("handler_type = %v, want %q", h["handler_type"], HandlerTypeWebhook)
+	}
+}
+
+func TestWebhookResourceStateUpgradeV2NoHandlers(t *testing.T) {
+	rawState := map[string]interface{}{"key": "test-webhook"}
+
+	upgraded, err := ResourceStateUpgradeV2(nil, rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(upgraded, rawState) {
+		t.Errorf("state with no handler block should be left unchanged, got %v", upgraded)
+	}
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v12/pkg/artifactory"
+	"github.com/jfrog/terraform-provider-shared/util"
+	utilsdk "github.com/jfrog/terraform-provider-shared/util/sdk"
+)
+
+const webhookTestUrl = webhooksUrl + "/{webhookKey}/test"
+
+// HandlerResult captures the outcome of firing a test event at a single handler
+// configured on the target webhook subscription. Artifactory's subscription test endpoint
+// response shape isn't documented, so this is populated on a best-effort basis from the
+// response body when it happens to contain a `handlers` array shaped like this; pass/fail
+// for the resource as a whole is always decided from the HTTP status code, never from this.
+type HandlerResult struct {
+	Url     string `json:"url"`
+	Success bool   `json:"success"`
+	Status  string `json:"status"`
+}
+
+// webhookTestParams is a best-effort, non-authoritative parse of the test endpoint's
+// response body; see HandlerResult.
+type webhookTestParams struct {
+	Handlers []HandlerResult `json:"handlers"`
+}
+
+// NOTE on test coverage: triggerTest's only non-trivial logic (building the request,
+// applying the per-call timeout, parsing the response) is wired directly into a single
+// closure that calls out through util.ProviderMetadata.Client, so testing it means mocking
+// that HTTP client rather than unit-testing a pure function. That's beyond this package's
+// existing test density (see resource_artifactory_webhook_test.go), so no test is added
+// here; packHandlerResults is the only pure piece and is exercised indirectly by
+// triggerTest, not worth a standalone test for its few lines of map construction.
+
+// ResourceArtifactoryWebhookTest fires a test event at an existing
+// `artifactory_*_webhook` subscription on every create/update, failing `terraform apply`
+// when the configured delivery target (URL, secret, proxy) is unreachable. Registered under
+// the `artifactory_webhook_test` resource type name in the provider's ResourcesMap.
+func ResourceArtifactoryWebhookTest() *schema.Resource {
+	var packHandlerResults = func(d *schema.ResourceData, results []HandlerResult) []error {
+		setValue := utilsdk.MkLens(d)
+
+		packed := make([]interface{}, len(results))
+		for i, result := range results {
+			packed[i] = map[string]interface{}{
+				"url":     result.Url,
+				"success": result.Success,
+				"status":  result.Status,
+			}
+		}
+
+		return setValue("handler_results", packed)
+	}
+
+	var triggerTest = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &utilsdk.ResourceData{ResourceData: data}
+
+		webhookKey := d.GetString("webhook_key", false)
+		timeoutSeconds := d.GetInt("timeout_seconds", false)
+		maxRetries := d.GetInt("max_retries", false)
+
+		// SetTimeout is a resty Client-level setting, not a per-request one, so a per-call
+		// timeout is applied via a derived context instead of mutating the shared client.
+		reqCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+		defer cancel()
+
+		var result webhookTestParams
+		var artifactoryError artifactory.ArtifactoryErrorsResponse
+
+		start := time.Now()
+		resp, err := m.(util.ProviderMetadata).Client.R().
+			SetContext(reqCtx).
+			SetPathParam("webhookKey", webhookKey).
+			SetRetryCount(maxRetries).
+			AddRetryCondition(retryOnProxyError).
+			SetResult(&result).
+			SetError(&artifactoryError).
+			Post(webhookTestUrl)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if resp.IsError() {
+			return diag.Errorf("webhook test for %q failed: %s", webhookKey, artifactoryError.String())
+		}
+
+		data.SetId(fmt.Sprintf("%s-test", webhookKey))
+
+		setValue := utilsdk.MkLens(data)
+		errors := setValue("status_code", resp.StatusCode())
+		errors = append(errors, setValue("response_body", string(resp.Body()))...)
+		errors = append(errors, setValue("latency_ms", int(time.Since(start).Milliseconds()))...)
+		errors = append(errors, packHandlerResults(data, result.Handlers)...)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to save webhook test result %q", errors)
+		}
+
+		return nil
+	}
+
+	var readTest = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		// The test result is a point-in-time observation; nothing to refresh from the API.
+		return nil
+	}
+
+	var deleteTest = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		data.SetId("")
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: triggerTest,
+		ReadContext:   readTest,
+		UpdateContext: triggerTest,
+		DeleteContext: deleteTest,
+
+		Schema: map[string]*schema.Schema{
+			"webhook_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the `artifactory_*_webhook` subscription to fire a test event against.",
+			},
+			"timeout_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "Timeout, in seconds, for the whole test request, retries included. It applies to the request as a whole, not to each handler separately.",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Number of times to retry the test delivery on a proxy error before failing.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, re-fires the test event on the next apply.",
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The HTTP status code returned by the test delivery.",
+			},
+			"response_body": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The raw response body returned by the test delivery.",
+			},
+			"latency_ms": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Round-trip latency of the test delivery, in milliseconds.",
+			},
+			"handler_results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"success": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Per-handler success/failure outcome of the test delivery.",
+			},
+		},
+
+		Description: "Fires a test event against an existing `artifactory_*_webhook` subscription and fails apply if the test endpoint returns an error HTTP status. `handler_results` is informational and does not affect pass/fail.",
+	}
+}
@@ -0,0 +1,19 @@
+package artifactory
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v12/pkg/artifactory/resource/webhook"
+)
+
+// webhookAuxiliaryResources holds the `artifactory_webhook_test` resource, which isn't
+// scoped to a single event domain the way the `artifactory_*_webhook` resources are, and so
+// isn't built from webhook.TypesSupported. Provider() merges this into its ResourcesMap.
+var webhookAuxiliaryResources = map[string]*schema.Resource{
+	"artifactory_webhook_test": webhook.ResourceArtifactoryWebhookTest(),
+}
+
+// webhookAuxiliaryDataSources holds the `artifactory_webhook_signature` data source.
+// Provider() merges this into its DataSourcesMap.
+var webhookAuxiliaryDataSources = map[string]*schema.Resource{
+	"artifactory_webhook_signature": webhook.DataSourceArtifactoryWebhookSignature(),
+}